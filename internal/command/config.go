@@ -0,0 +1,260 @@
+// Package command implements noti's configuration resolution and
+// command-line plumbing: flags, config file discovery, environment
+// variable bindings, and wiring the enabled notification services.
+package command
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// baseDefaults holds the compiled-in default for every viper key noti
+// understands. Keys use viper's dotted notation so that related settings,
+// like a service's credentials, nest under a common prefix.
+var baseDefaults = map[string]string{
+	"default":                      "banner",
+	"noti.allowEmptyEnv":           "false",
+	"nsuser.soundName":             "default",
+	"slackConfig.token":            "",
+	"slackConfig.channel":          "",
+	"hipchatConfig.token":          "",
+	"hipchatConfig.room":           "",
+	"pushbulletConfig.accessToken": "",
+	"pushoverConfig.token":         "",
+	"pushoverConfig.user":          "",
+	"pushsaferConfig.key":          "",
+	"simplepushConfig.key":         "",
+	"bearychatConfig.webhook":      "",
+}
+
+// keyEnvBindings maps each viper key to the environment variables that
+// override it, in precedence order. Most keys only ever need their
+// NOTI_-prefixed name, but some also honor the vendor-native variable a
+// user's shell profile is already likely to export, e.g.
+// slackConfig.token checks NOTI_SLACK_TOKEN before falling back to
+// SLACK_TOKEN.
+var keyEnvBindings = map[string][]string{
+	"default":                      {"NOTI_DEFAULT"},
+	"noti.allowEmptyEnv":           {"NOTI_ALLOW_EMPTY_ENV"},
+	"nsuser.soundName":             {"NOTI_NSUSER_SOUNDNAME"},
+	"slackConfig.token":            {"NOTI_SLACK_TOKEN", "SLACK_TOKEN"},
+	"slackConfig.channel":          {"NOTI_SLACK_CHANNEL"},
+	"hipchatConfig.token":          {"NOTI_HIPCHAT_TOKEN"},
+	"hipchatConfig.room":           {"NOTI_HIPCHAT_ROOM"},
+	"pushbulletConfig.accessToken": {"NOTI_PUSHBULLET_ACCESS_TOKEN", "PUSHBULLET_ACCESS_TOKEN"},
+	"pushoverConfig.token":         {"NOTI_PUSHOVER_TOKEN", "PUSHOVER_TOKEN"},
+	"pushoverConfig.user":          {"NOTI_PUSHOVER_USER"},
+	"pushsaferConfig.key":          {"NOTI_PUSHSAFER_KEY"},
+	"simplepushConfig.key":         {"NOTI_SIMPLEPUSH_KEY"},
+	"bearychatConfig.webhook":      {"NOTI_BEARYCHAT_WEBHOOK"},
+}
+
+// serviceNames lists every notification service noti can send through, in
+// the order they're offered to users.
+var serviceNames = []string{
+	"banner",
+	"bearychat",
+	"hipchat",
+	"pushbullet",
+	"pushover",
+	"pushsafer",
+	"simplepush",
+	"slack",
+	"speech",
+}
+
+// setNotiDefaults registers noti's compiled-in defaults on v.
+func setNotiDefaults(v *viper.Viper) {
+	for key, val := range baseDefaults {
+		v.SetDefault(key, val)
+	}
+}
+
+// bindNotiEnv binds every key in keyEnvBindings to its environment
+// variable aliases. Earlier aliases take precedence over later ones. By
+// default, an env var that's set but empty is treated the same as unset,
+// so the config file or compiled-in default still applies; allowEmpty
+// makes an explicitly empty env var win instead.
+func bindNotiEnv(v *viper.Viper, allowEmpty bool) {
+	v.AllowEmptyEnv(allowEmpty)
+	for key, aliases := range keyEnvBindings {
+		v.BindEnv(append([]string{key}, aliases...)...)
+	}
+}
+
+// allowEmptyEnvFlag reports whether --allow-empty-env was passed on the
+// command line.
+func allowEmptyEnvFlag(flags *pflag.FlagSet) bool {
+	f := flags.Lookup("allow-empty-env")
+	if f == nil {
+		return false
+	}
+	allow, err := strconv.ParseBool(f.Value.String())
+	return err == nil && allow
+}
+
+// resolveAllowEmptyEnv determines whether empty environment variables
+// should be treated as explicit overrides, checking, in order:
+// --allow-empty-env, NOTI_ALLOW_EMPTY_ENV (read directly, since
+// bindNotiEnv hasn't bound the environment yet), and finally the
+// noti.allowEmptyEnv config file setting or its compiled-in default. v
+// must already have setNotiDefaults and setupConfigFile applied.
+func resolveAllowEmptyEnv(v *viper.Viper, flags *pflag.FlagSet) bool {
+	if allowEmptyEnvFlag(flags) {
+		return true
+	}
+
+	if raw := os.Getenv("NOTI_ALLOW_EMPTY_ENV"); raw != "" {
+		if allow, err := strconv.ParseBool(raw); err == nil {
+			return allow
+		}
+	}
+
+	return v.GetBool("noti.allowEmptyEnv")
+}
+
+// configSearchPaths returns noti's config file candidates, in the order
+// they're checked: the current directory, the XDG config dir, the
+// traditional ~/.config layout, the legacy dotfile some older versions
+// of noti wrote to $HOME, and finally a system-wide file in /etc.
+func configSearchPaths() []string {
+	paths := []string{filepath.Join(".", "noti.yaml")}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "noti", "noti.yaml"))
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths,
+			filepath.Join(home, ".config", "noti", "noti.yaml"),
+			filepath.Join(home, ".noti.yaml"),
+		)
+	}
+
+	return append(paths, filepath.Join("/etc", "noti", "noti.yaml"))
+}
+
+// setupConfigFile points v at noti's config file and loads it, if
+// present. --config overrides the normal search entirely; otherwise noti
+// walks configSearchPaths and uses the first one that exists. A missing
+// config file isn't an error; noti runs fine on defaults and environment
+// variables alone.
+func setupConfigFile(v *viper.Viper, flags *pflag.FlagSet) {
+	verbose, _ := flags.GetBool("verbose")
+
+	if path, _ := flags.GetString("config"); path != "" {
+		if verbose {
+			fmt.Fprintln(os.Stderr, "noti: using config file from --config:", path)
+		}
+		v.SetConfigFile(path)
+		readConfigFile(v, verbose)
+		return
+	}
+
+	for _, path := range configSearchPaths() {
+		if verbose {
+			fmt.Fprintln(os.Stderr, "noti: looking for config file:", path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		v.SetConfigFile(path)
+		break
+	}
+
+	readConfigFile(v, verbose)
+}
+
+// readConfigFile loads whatever config file v was pointed at by
+// setupConfigFile, if any.
+func readConfigFile(v *viper.Viper, verbose bool) {
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			fmt.Fprintln(os.Stderr, "noti: error reading config file:", err)
+		}
+		return
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, "noti: using config file:", v.ConfigFileUsed())
+	}
+}
+
+// defineFlags registers noti's command-line flags on flags.
+func defineFlags(flags *pflag.FlagSet) {
+	flags.Bool("verbose", false, "print extra diagnostic information")
+	flags.String("config", "", "path to a config file, overriding noti's normal search locations")
+	flags.Bool("allow-empty-env", false, "treat an explicitly empty environment variable as an override instead of falling through")
+	flags.Bool("watch", false, "keep running and reload services whenever the config file changes")
+	flags.Bool("json", false, "print `noti config` output as JSON instead of a table")
+
+	flags.Bool("banner", false, "send a desktop banner notification")
+	flags.Bool("bearychat", false, "send a BearyChat notification")
+	flags.Bool("hipchat", false, "send a HipChat notification")
+	flags.Bool("pushbullet", false, "send a Pushbullet notification")
+	flags.Bool("pushover", false, "send a Pushover notification")
+	flags.Bool("pushsafer", false, "send a Pushsafer notification")
+	flags.Bool("simplepush", false, "send a SimplePush notification")
+	flags.Bool("slack", false, "send a Slack notification")
+	flags.Bool("speech", false, "send a speech notification")
+}
+
+// configureApp wires up v with noti's full resolution order: compiled-in
+// defaults, a remote config provider (when built with the remoteconfig
+// tag), the local config file, environment variables, and finally flags.
+func configureApp(v *viper.Viper, flags *pflag.FlagSet) {
+	setNotiDefaults(v)
+	if err := setupRemoteConfig(v); err != nil {
+		fmt.Fprintln(os.Stderr, "noti:", err)
+	}
+	setupConfigFile(v, flags)
+	bindNotiEnv(v, resolveAllowEmptyEnv(v, flags))
+	v.BindPFlags(flags)
+	v.BindPFlag("noti.allowEmptyEnv", flags.Lookup("allow-empty-env"))
+}
+
+// isServiceFlag reports whether name is one of the notification service
+// flags, as opposed to a general flag like --verbose.
+func isServiceFlag(name string) bool {
+	for _, s := range serviceNames {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// enabledServices resolves which notification services are active for this
+// run. A service flag passed on the command line always wins; otherwise
+// noti falls back to the "default" setting, which is itself resolvable
+// from the config file or NOTI_DEFAULT.
+func enabledServices(v *viper.Viper, flags *pflag.FlagSet) map[string]struct{} {
+	services := make(map[string]struct{})
+
+	var flagSet bool
+	flags.Visit(func(f *pflag.Flag) {
+		if !isServiceFlag(f.Name) {
+			return
+		}
+		flagSet = true
+		if f.Value.String() == "true" {
+			services[f.Name] = struct{}{}
+		}
+	})
+
+	if flagSet {
+		return services
+	}
+
+	for _, name := range strings.Fields(v.GetString("default")) {
+		services[name] = struct{}{}
+	}
+
+	return services
+}
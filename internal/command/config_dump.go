@@ -0,0 +1,121 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// settingSource describes one resolved configuration key: its final value
+// and which layer supplied it.
+type settingSource struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// resolveSources walks every key noti understands and determines which
+// layer supplied its final value, following the same precedence
+// configureApp itself uses: flag, then env, then config file, then
+// compiled-in default. A value supplied by a remote config provider is
+// reported as "default"; the remote layer is not identified.
+func resolveSources(v *viper.Viper, flags *pflag.FlagSet) []settingSource {
+	keys := make([]string, 0, len(baseDefaults))
+	for key := range baseDefaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make([]settingSource, 0, len(keys))
+	for _, key := range keys {
+		source, detail := keySource(v, flags, key)
+		settings = append(settings, settingSource{
+			Key:    key,
+			Value:  v.GetString(key),
+			Source: source,
+			Detail: detail,
+		})
+	}
+
+	return settings
+}
+
+// keyFlagNames maps a config key to the flag that can override it, for
+// the handful of keys whose flag name doesn't match the key itself (most
+// keys, like slackConfig.token, have no corresponding flag at all).
+var keyFlagNames = map[string]string{
+	"noti.allowEmptyEnv": "allow-empty-env",
+}
+
+// keySource reports which layer supplied key's value, along with a detail
+// string identifying that layer: the flag name, the actual env var that
+// was set, or the config file path.
+func keySource(v *viper.Viper, flags *pflag.FlagSet, key string) (source, detail string) {
+	flagName := key
+	if name, ok := keyFlagNames[key]; ok {
+		flagName = name
+	}
+	if f := flags.Lookup(flagName); f != nil && f.Changed {
+		return "flag", "--" + flagName
+	}
+
+	allowEmpty := resolveAllowEmptyEnv(v, flags)
+	for _, env := range keyEnvBindings[key] {
+		if val, ok := os.LookupEnv(env); ok && (val != "" || allowEmpty) {
+			return "env", env
+		}
+	}
+
+	if path := v.ConfigFileUsed(); path != "" && v.InConfig(key) {
+		return "file", path
+	}
+
+	return "default", ""
+}
+
+// IsConfigCommand reports whether args invoke noti's `config` subcommand,
+// e.g. `noti config` or `noti config --json`.
+func IsConfigCommand(args []string) bool {
+	return len(args) > 0 && args[0] == "config"
+}
+
+// DispatchConfig runs the `noti config` subcommand when args invokes it,
+// reading --json from flags to pick the output format. It reports
+// whether it ran, so callers fall back to their normal notification flow
+// when args doesn't name the config subcommand.
+func DispatchConfig(args []string, w io.Writer, v *viper.Viper, flags *pflag.FlagSet) (bool, error) {
+	if !IsConfigCommand(args) {
+		return false, nil
+	}
+
+	jsonOutput, _ := flags.GetBool("json")
+	return true, RunConfig(w, v, flags, jsonOutput)
+}
+
+// RunConfig implements `noti config`: it prints the fully-resolved
+// configuration and, for every key, which layer supplied the value, so
+// operators can debug misconfigured credentials without guessing. With
+// jsonOutput, it prints a machine-readable form instead of a table.
+func RunConfig(w io.Writer, v *viper.Viper, flags *pflag.FlagSet, jsonOutput bool) error {
+	settings := resolveSources(v, flags)
+
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE\tDETAIL")
+	for _, s := range settings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", s.Key, s.Value, s.Source, s.Detail)
+	}
+	return tw.Flush()
+}
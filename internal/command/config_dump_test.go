@@ -0,0 +1,116 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestIsConfigCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: nil, want: false},
+		{args: []string{}, want: false},
+		{args: []string{"config"}, want: true},
+		{args: []string{"config", "--json"}, want: true},
+		{args: []string{"serve"}, want: false},
+	}
+
+	for _, tt := range tests {
+		if have := IsConfigCommand(tt.args); have != tt.want {
+			t.Errorf("IsConfigCommand(%v) = %t; want %t", tt.args, have, tt.want)
+		}
+	}
+}
+
+func TestDispatchConfig(t *testing.T) {
+	newApp := func(t *testing.T) (*viper.Viper, *pflag.FlagSet) {
+		t.Helper()
+
+		orig := getNotiEnv(t)
+		t.Cleanup(func() { setNotiEnv(t, orig) })
+		clearNotiEnv(t)
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testdispatchconfig", pflag.ContinueOnError)
+		defineFlags(flags)
+
+		configureApp(v, flags)
+		return v, flags
+	}
+
+	t.Run("not the config subcommand", func(t *testing.T) {
+		v, flags := newApp(t)
+
+		var buf bytes.Buffer
+		handled, err := DispatchConfig([]string{"serve"}, &buf, v, flags)
+		if handled {
+			t.Error("Expected DispatchConfig to report unhandled")
+		}
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+		}
+		if buf.Len() != 0 {
+			t.Error("Expected no output when unhandled")
+		}
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		v, flags := newApp(t)
+
+		var buf bytes.Buffer
+		handled, err := DispatchConfig([]string{"config"}, &buf, v, flags)
+		if !handled {
+			t.Error("Expected DispatchConfig to report handled")
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(buf.String(), "KEY") {
+			t.Errorf("Expected table header in output, got: %s", buf.String())
+		}
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		v, flags := newApp(t)
+		flags.Set("json", "true")
+
+		var buf bytes.Buffer
+		handled, err := DispatchConfig([]string{"config"}, &buf, v, flags)
+		if !handled {
+			t.Error("Expected DispatchConfig to report handled")
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var settings []settingSource
+		if err := json.Unmarshal(buf.Bytes(), &settings); err != nil {
+			t.Fatalf("Expected valid JSON output: %s", err)
+		}
+		if len(settings) != len(baseDefaults) {
+			t.Errorf("have=%d settings; want=%d", len(settings), len(baseDefaults))
+		}
+	})
+}
+
+func TestKeySourceFlagProvenance(t *testing.T) {
+	v := viper.New()
+	flags := pflag.NewFlagSet("testkeysourceflag", pflag.ContinueOnError)
+	defineFlags(flags)
+	flags.Set("allow-empty-env", "true")
+
+	source, detail := keySource(v, flags, "noti.allowEmptyEnv")
+	if source != "flag" {
+		t.Errorf("source=%q; want=flag", source)
+	}
+	if detail != "--allow-empty-env" {
+		t.Errorf("detail=%q; want=--allow-empty-env", detail)
+	}
+}
@@ -45,11 +45,21 @@ func TestSetNotiDefaults(t *testing.T) {
 	}
 }
 
+// flattenedEnvNames returns every environment variable name bound to any
+// config key, across all aliases.
+func flattenedEnvNames() []string {
+	var names []string
+	for _, aliases := range keyEnvBindings {
+		names = append(names, aliases...)
+	}
+	return names
+}
+
 func getNotiEnv(t *testing.T) map[string]string {
 	t.Helper()
 
 	notiEnv := make(map[string]string)
-	for _, env := range keyEnvBindings {
+	for _, env := range flattenedEnvNames() {
 		notiEnv[env] = os.Getenv(env)
 	}
 	return notiEnv
@@ -58,7 +68,7 @@ func getNotiEnv(t *testing.T) map[string]string {
 func clearNotiEnv(t *testing.T) {
 	t.Helper()
 
-	for _, env := range keyEnvBindings {
+	for _, env := range flattenedEnvNames() {
 		if err := os.Unsetenv(env); err != nil {
 			t.Fatalf("failed to clear noti env: %s", err)
 		}
@@ -82,7 +92,7 @@ func TestBindNotiEnv(t *testing.T) {
 	clearNotiEnv(t)
 
 	v := viper.New()
-	bindNotiEnv(v)
+	bindNotiEnv(v, false)
 
 	haveKeys := countSettingsKeys(t, v.AllSettings())
 	if haveKeys != 0 {
@@ -90,17 +100,16 @@ func TestBindNotiEnv(t *testing.T) {
 		t.Error(v.AllSettings())
 	}
 
-	var numSet int
-	for _, env := range keyEnvBindings {
+	for _, env := range flattenedEnvNames() {
 		if err := os.Setenv(env, "foo"); err != nil {
 			t.Errorf("Setenv error: %s", err)
-			continue
 		}
-		numSet++
 	}
 
+	// Multiple aliases resolve to a single key, so the count of settings
+	// keys tracks keyEnvBindings, not the (larger) number of env vars set.
 	haveKeys = countSettingsKeys(t, v.AllSettings())
-	wantKeys := numSet
+	wantKeys := len(keyEnvBindings)
 	if haveKeys != wantKeys {
 		t.Error("Unexpected base config length")
 		t.Errorf("have=%d; want=%d", haveKeys, wantKeys)
@@ -108,32 +117,148 @@ func TestBindNotiEnv(t *testing.T) {
 	}
 }
 
-func TestSetupConfigFile(t *testing.T) {
-	v := viper.New()
-	// For tests, we prepend the testdata dir so that we check for a config
-	// file there first.
-	v.AddConfigPath("testdata")
-	setupConfigFile(v)
-
-	const want = 1
-	have := countSettingsKeys(t, v.AllSettings())
-	if have != want {
-		t.Error("Unexpected number of keys")
-		t.Errorf("have=%d; want=%d", have, want)
+func TestBindNotiEnvAllowEmpty(t *testing.T) {
+	orig := getNotiEnv(t)
+	defer setNotiEnv(t, orig)
+
+	clearNotiEnv(t)
+	if err := os.Setenv("NOTI_SLACK_CHANNEL", ""); err != nil {
+		t.Fatalf("failed to set noti env: %s", err)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		v := viper.New()
+		bindNotiEnv(v, false)
+
+		if v.IsSet("slackConfig.channel") {
+			t.Error("An explicitly empty env var should be ignored in strict mode")
+		}
+	})
+
+	t.Run("allow empty", func(t *testing.T) {
+		v := viper.New()
+		bindNotiEnv(v, true)
+
+		if !v.IsSet("slackConfig.channel") {
+			t.Error("An explicitly empty env var should be honored in allow-empty mode")
+		}
+		if have := v.GetString("slackConfig.channel"); have != "" {
+			t.Errorf("have=%q; want empty string", have)
+		}
+	})
+}
+
+// chdir switches the current directory to dir for the duration of the
+// test, restoring it on cleanup.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(orig) })
+}
+
+// writeConfig writes a minimal noti config file setting nsuser.soundName
+// to want, creating parent directories as needed.
+func writeConfig(t *testing.T, path, want string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contents := fmt.Sprintf("nsuser:\n  soundName: %s\n", want)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
 	}
 }
 
+func TestSetupConfigFile(t *testing.T) {
+	t.Run("--config override", func(t *testing.T) {
+		v := viper.New()
+		flags := pflag.NewFlagSet("testsetupconfigfileoverride", pflag.ContinueOnError)
+		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+
+		setupConfigFile(v, flags)
+
+		want := "testdata"
+		if have := v.GetString("nsuser.soundName"); have != want {
+			t.Errorf("have=%s; want=%s", have, want)
+		}
+	})
+
+	t.Run("XDG_CONFIG_HOME", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, filepath.Join(dir, "xdg", "noti", "noti.yaml"), "xdg")
+
+		chdir(t, t.TempDir())
+		t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
+		t.Setenv("HOME", filepath.Join(dir, "home-unused"))
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testsetupconfigfilexdg", pflag.ContinueOnError)
+		defineFlags(flags)
+
+		setupConfigFile(v, flags)
+
+		want := "xdg"
+		if have := v.GetString("nsuser.soundName"); have != want {
+			t.Errorf("have=%s; want=%s", have, want)
+		}
+	})
+
+	t.Run("home dotfile fallback", func(t *testing.T) {
+		dir := t.TempDir()
+		writeConfig(t, filepath.Join(dir, "home", ".noti.yaml"), "dotfile")
+
+		chdir(t, t.TempDir())
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", filepath.Join(dir, "home"))
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testsetupconfigfiledotfile", pflag.ContinueOnError)
+		defineFlags(flags)
+
+		setupConfigFile(v, flags)
+
+		want := "dotfile"
+		if have := v.GetString("nsuser.soundName"); have != want {
+			t.Errorf("have=%s; want=%s", have, want)
+		}
+	})
+
+	t.Run("no config file found", func(t *testing.T) {
+		chdir(t, t.TempDir())
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", t.TempDir())
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testsetupconfigfilemissing", pflag.ContinueOnError)
+		defineFlags(flags)
+
+		setupConfigFile(v, flags)
+
+		have := countSettingsKeys(t, v.AllSettings())
+		if have != 0 {
+			t.Errorf("Expected no settings to be loaded, got %d", have)
+		}
+	})
+}
+
 func TestConfigureApp(t *testing.T) {
 	orig := getNotiEnv(t)
 	defer setNotiEnv(t, orig)
 	clearNotiEnv(t)
 
 	v := viper.New()
-	// For tests, we prepend the testdata dir so that we check for a config
-	// file there first.
-	v.AddConfigPath("testdata")
 	flags := pflag.NewFlagSet("testconfigureapp", pflag.ContinueOnError)
 	defineFlags(flags)
+	flags.Set("config", filepath.Join("testdata", "noti.yaml"))
 
 	configureApp(v, flags)
 
@@ -184,6 +309,87 @@ func TestConfigureApp(t *testing.T) {
 	})
 }
 
+func TestConfigureAppAllowEmptyEnv(t *testing.T) {
+	orig := getNotiEnv(t)
+	defer setNotiEnv(t, orig)
+	clearNotiEnv(t)
+
+	// "default" has a non-empty compiled-in default ("banner"), so an
+	// explicitly empty NOTI_DEFAULT only shows up as a real override: in
+	// strict mode it's indistinguishable from "unset" and noti falls back
+	// to the default; in allow-empty mode it wins.
+	if err := os.Setenv("NOTI_DEFAULT", ""); err != nil {
+		t.Fatalf("Failed to set env: %s", err)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		v := viper.New()
+		flags := pflag.NewFlagSet("testconfigureappstrict", pflag.ContinueOnError)
+		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+
+		configureApp(v, flags)
+
+		have := v.GetString("default")
+		want := baseDefaults["default"]
+		if have != want {
+			t.Errorf("have=%q; want=%q", have, want)
+		}
+	})
+
+	t.Run("allow empty", func(t *testing.T) {
+		v := viper.New()
+		flags := pflag.NewFlagSet("testconfigureappallowempty", pflag.ContinueOnError)
+		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+		flags.Set("allow-empty-env", "true")
+
+		configureApp(v, flags)
+
+		if have := v.GetString("default"); have != "" {
+			t.Errorf("have=%q; want empty string", have)
+		}
+	})
+
+	t.Run("allow empty via NOTI_ALLOW_EMPTY_ENV", func(t *testing.T) {
+		if err := os.Setenv("NOTI_ALLOW_EMPTY_ENV", "true"); err != nil {
+			t.Fatalf("Failed to set env: %s", err)
+		}
+		defer os.Unsetenv("NOTI_ALLOW_EMPTY_ENV")
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testconfigureappallowemptyviaenv", pflag.ContinueOnError)
+		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+
+		configureApp(v, flags)
+
+		if have := v.GetString("default"); have != "" {
+			t.Errorf("have=%q; want empty string", have)
+		}
+	})
+
+	t.Run("allow empty via config file setting", func(t *testing.T) {
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, "noti.yaml")
+		contents := "noti:\n  allowEmptyEnv: true\n"
+		if err := os.WriteFile(configPath, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		v := viper.New()
+		flags := pflag.NewFlagSet("testconfigureappallowemptyviafile", pflag.ContinueOnError)
+		defineFlags(flags)
+		flags.Set("config", configPath)
+
+		configureApp(v, flags)
+
+		if have := v.GetString("default"); have != "" {
+			t.Errorf("have=%q; want empty string", have)
+		}
+	})
+}
+
 func TestEnabledServices(t *testing.T) {
 	orig := getNotiEnv(t)
 	defer setNotiEnv(t, orig)
@@ -191,12 +397,9 @@ func TestEnabledServices(t *testing.T) {
 
 	t.Run("flag override", func(t *testing.T) {
 		v := viper.New()
-		// For tests, we prepend the testdata dir so that we check for a config
-		// file there first.
-		v.AddConfigPath("testdata")
-
 		flags := pflag.NewFlagSet("testenabledservices", pflag.ContinueOnError)
 		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
 
 		configureApp(v, flags)
 
@@ -218,12 +421,9 @@ func TestEnabledServices(t *testing.T) {
 
 	t.Run("non-service flags", func(t *testing.T) {
 		v := viper.New()
-		// For tests, we prepend the testdata dir so that we check for a config
-		// file there first.
-		v.AddConfigPath("testdata")
-
 		flags := pflag.NewFlagSet("testenabledservices", pflag.ContinueOnError)
 		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
 
 		configureApp(v, flags)
 
@@ -248,12 +448,9 @@ func TestEnabledServices(t *testing.T) {
 
 	t.Run("env override", func(t *testing.T) {
 		v := viper.New()
-		// For tests, we prepend the testdata dir so that we check for a config
-		// file there first.
-		v.AddConfigPath("testdata")
-
 		flags := pflag.NewFlagSet("testenabledservices", pflag.ContinueOnError)
 		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
 
 		configureApp(v, flags)
 
@@ -279,12 +476,9 @@ func TestEnabledServices(t *testing.T) {
 
 	t.Run("defaults", func(t *testing.T) {
 		v := viper.New()
-		// For tests, we prepend the testdata dir so that we check for a config
-		// file there first.
-		v.AddConfigPath("testdata")
-
 		flags := pflag.NewFlagSet("testenabledservices", pflag.ContinueOnError)
 		defineFlags(flags)
+		flags.Set("config", filepath.Join("testdata", "noti.yaml"))
 
 		configureApp(v, flags)
 
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"github.com/kidzen/noti"
+	"github.com/kidzen/noti/banner"
+	"github.com/kidzen/noti/bearychat"
+	"github.com/kidzen/noti/hipchat"
+	"github.com/kidzen/noti/pushbullet"
+	"github.com/kidzen/noti/pushover"
+	"github.com/kidzen/noti/pushsafer"
+	"github.com/kidzen/noti/simplepush"
+	"github.com/kidzen/noti/slack"
+	"github.com/kidzen/noti/speech"
+
+	"github.com/spf13/viper"
+)
+
+// Notification pairs a notification service's API endpoint with the
+// function that delivers a message through it.
+type Notification struct {
+	Name   string
+	API    string
+	Notify func(noti.Params) error
+}
+
+// allNotifications lists every notification service noti knows how to
+// send through, in the order they're offered to users.
+var allNotifications = []Notification{
+	{Name: "banner", Notify: banner.Notify},
+	{Name: "bearychat", Notify: bearychat.Notify},
+	{Name: "hipchat", API: hipchat.API, Notify: hipchat.Notify},
+	{Name: "pushbullet", API: pushbullet.API, Notify: pushbullet.Notify},
+	{Name: "pushover", API: pushover.API, Notify: pushover.Notify},
+	{Name: "pushsafer", API: pushsafer.API, Notify: pushsafer.Notify},
+	{Name: "simplepush", API: simplepush.API, Notify: simplepush.Notify},
+	{Name: "slack", API: slack.API, Notify: slack.Notify},
+	{Name: "speech", Notify: speech.Notify},
+}
+
+// getNotifications builds the Notification set for every service name
+// present in services. v isn't consulted yet, but it's accepted here so
+// that services can grow config-driven knobs (e.g. a custom API endpoint)
+// without changing this signature.
+func getNotifications(v *viper.Viper, services map[string]struct{}) []Notification {
+	var notis []Notification
+	for _, n := range allNotifications {
+		if _, ok := services[n.Name]; ok {
+			notis = append(notis, n)
+		}
+	}
+
+	return notis
+}
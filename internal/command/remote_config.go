@@ -0,0 +1,13 @@
+//go:build !remoteconfig
+
+package command
+
+import "github.com/spf13/viper"
+
+// setupRemoteConfig is a no-op in the default build. Remote config
+// providers (etcd, Consul) pull in viper/remote and its backend client
+// libraries, so they're only compiled in with the remoteconfig build
+// tag; see remote_config_remote.go.
+func setupRemoteConfig(v *viper.Viper) error {
+	return nil
+}
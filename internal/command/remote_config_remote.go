@@ -0,0 +1,52 @@
+//go:build remoteconfig
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	_ "github.com/spf13/viper/remote"
+)
+
+// Environment variables that configure setupRemoteConfig's remote
+// provider. They're read directly, rather than through keyEnvBindings,
+// because the remote provider has to be reachable before bindNotiEnv
+// and setupConfigFile run.
+const (
+	envRemoteProvider = "NOTI_REMOTE_PROVIDER" // "etcd3" or "consul"
+	envRemoteEndpoint = "NOTI_REMOTE_ENDPOINT"
+	envRemotePath     = "NOTI_REMOTE_PATH"
+)
+
+// defaultRemotePath is used when NOTI_REMOTE_PATH isn't set.
+const defaultRemotePath = "/noti/config"
+
+// setupRemoteConfig adds and reads a remote config provider, if
+// NOTI_REMOTE_PROVIDER and NOTI_REMOTE_ENDPOINT are set. Like the local
+// config file, a missing or unreachable remote config isn't fatal: noti
+// falls back to defaults, and the local config file and environment
+// variables both still take precedence over whatever the remote
+// provider supplies.
+func setupRemoteConfig(v *viper.Viper) error {
+	provider := os.Getenv(envRemoteProvider)
+	endpoint := os.Getenv(envRemoteEndpoint)
+	if provider == "" || endpoint == "" {
+		return nil
+	}
+
+	path := os.Getenv(envRemotePath)
+	if path == "" {
+		path = defaultRemotePath
+	}
+
+	v.SetConfigType("yaml")
+	if err := v.AddRemoteProvider(provider, endpoint, path); err != nil {
+		return fmt.Errorf("noti: error configuring remote provider %s: %w", provider, err)
+	}
+	if err := v.ReadRemoteConfig(); err != nil {
+		fmt.Fprintln(os.Stderr, "noti: error reading remote config:", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+//go:build remoteconfig
+
+package command
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// stubRemoteReader implements viper's remoteConfigFactory interface so
+// tests can exercise setupRemoteConfig without a real etcd or Consul
+// cluster.
+type stubRemoteReader struct {
+	yaml string
+	err  error
+}
+
+func (s stubRemoteReader) Get(rp viper.RemoteProvider) (io.Reader, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return bytes.NewReader([]byte(s.yaml)), nil
+}
+
+func (s stubRemoteReader) Watch(rp viper.RemoteProvider) (io.Reader, error) {
+	return s.Get(rp)
+}
+
+func (s stubRemoteReader) WatchChannel(rp viper.RemoteProvider) (<-chan *viper.RemoteResponse, chan bool) {
+	return nil, nil
+}
+
+func TestSetupRemoteConfig(t *testing.T) {
+	origReader := viper.RemoteConfig
+	defer func() { viper.RemoteConfig = origReader }()
+
+	tests := []struct {
+		name     string
+		provider string
+		endpoint string
+		reader   stubRemoteReader
+		wantVal  string
+	}{
+		{
+			name:     "no provider configured",
+			provider: "",
+			endpoint: "",
+			reader:   stubRemoteReader{},
+			wantVal:  "",
+		},
+		{
+			name:     "remote config applies",
+			provider: "etcd3",
+			endpoint: "http://127.0.0.1:2379",
+			reader:   stubRemoteReader{yaml: "default: slack\n"},
+			wantVal:  "slack",
+		},
+		{
+			name:     "unreachable remote isn't fatal",
+			provider: "etcd3",
+			endpoint: "http://127.0.0.1:2379",
+			reader:   stubRemoteReader{err: io.ErrUnexpectedEOF},
+			wantVal:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.RemoteConfig = tt.reader
+
+			os.Setenv(envRemoteProvider, tt.provider)
+			os.Setenv(envRemoteEndpoint, tt.endpoint)
+			defer os.Unsetenv(envRemoteProvider)
+			defer os.Unsetenv(envRemoteEndpoint)
+
+			v := viper.New()
+			if err := setupRemoteConfig(v); err != nil {
+				t.Fatalf("setupRemoteConfig() error = %v", err)
+			}
+
+			if have := v.GetString("default"); have != tt.wantVal {
+				t.Errorf("have=%q; want=%q", have, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestConfigureAppRemotePrecedence(t *testing.T) {
+	origReader := viper.RemoteConfig
+	defer func() { viper.RemoteConfig = origReader }()
+
+	viper.RemoteConfig = stubRemoteReader{yaml: "default: slack\n"}
+	os.Setenv(envRemoteProvider, "etcd3")
+	os.Setenv(envRemoteEndpoint, "http://127.0.0.1:2379")
+	defer os.Unsetenv(envRemoteProvider)
+	defer os.Unsetenv(envRemoteEndpoint)
+
+	orig := getNotiEnv(t)
+	defer setNotiEnv(t, orig)
+	clearNotiEnv(t)
+
+	v := viper.New()
+	flags := pflag.NewFlagSet("testconfigureappremote", pflag.ContinueOnError)
+	defineFlags(flags)
+	// The local config file, loaded from testdata, doesn't set "default",
+	// so the remote value should win over the compiled-in default.
+	flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+
+	configureApp(v, flags)
+
+	if have, want := v.GetString("default"), "slack"; have != want {
+		t.Errorf("remote config should beat compiled-in default: have=%q; want=%q", have, want)
+	}
+}
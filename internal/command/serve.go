@@ -0,0 +1,91 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Daemon holds noti's live, reloadable configuration state for `noti
+// serve` / --watch mode: the set of enabled services and the
+// notifications built from them. A config file change replaces both, so
+// every send after a reload sees a consistent, fully up-to-date snapshot
+// without blocking on in-flight sends that started under the old one.
+type Daemon struct {
+	v     *viper.Viper
+	flags *pflag.FlagSet
+
+	mu       sync.RWMutex
+	services map[string]struct{}
+
+	notifications atomic.Value // []Notification
+}
+
+// NewDaemon builds a Daemon from an already-configured v and flags,
+// resolving the initial set of enabled services and notifications.
+func NewDaemon(v *viper.Viper, flags *pflag.FlagSet) *Daemon {
+	d := &Daemon{v: v, flags: flags}
+	d.reload()
+	return d
+}
+
+// reload re-resolves the enabled services and notifications from the
+// current state of d.v and d.flags, and swaps them in.
+func (d *Daemon) reload() {
+	services := enabledServices(d.v, d.flags)
+
+	d.mu.Lock()
+	d.services = services
+	d.mu.Unlock()
+
+	d.notifications.Store(getNotifications(d.v, services))
+}
+
+// Services returns a copy of the currently enabled service set.
+func (d *Daemon) Services() map[string]struct{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	services := make(map[string]struct{}, len(d.services))
+	for name := range d.services {
+		services[name] = struct{}{}
+	}
+	return services
+}
+
+// Notifications returns the Notification set built from the currently
+// enabled services.
+func (d *Daemon) Notifications() []Notification {
+	return d.notifications.Load().([]Notification)
+}
+
+// Watch starts watching the config file for changes, reloading d's state
+// whenever it changes. It returns immediately; the watch itself runs on
+// viper's own background goroutine. A nil w suppresses the reload log
+// line.
+func (d *Daemon) Watch(w io.Writer) {
+	d.v.OnConfigChange(func(e fsnotify.Event) {
+		d.reload()
+		if w != nil {
+			fmt.Fprintf(w, "noti: config changed (%s), reloaded\n", e.Name)
+		}
+	})
+	d.v.WatchConfig()
+}
+
+// RunServe implements `noti serve` (and plain `noti --watch`): it builds
+// a Daemon from v and flags, starts watching the config file for
+// changes, and blocks until stop is closed. Callers that only want a
+// one-shot send should use getNotifications directly instead.
+func RunServe(w io.Writer, v *viper.Viper, flags *pflag.FlagSet, stop <-chan struct{}) error {
+	d := NewDaemon(v, flags)
+	d.Watch(w)
+
+	<-stop
+	return nil
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+func TestDaemonReload(t *testing.T) {
+	orig := getNotiEnv(t)
+	defer setNotiEnv(t, orig)
+	clearNotiEnv(t)
+
+	v := viper.New()
+	flags := pflag.NewFlagSet("testdaemonreload", pflag.ContinueOnError)
+	defineFlags(flags)
+	flags.Set("config", filepath.Join("testdata", "noti.yaml"))
+
+	configureApp(v, flags)
+
+	d := NewDaemon(v, flags)
+
+	if _, ok := d.Services()["banner"]; !ok {
+		t.Fatal("Expected banner service to be enabled by default")
+	}
+	if len(d.Notifications()) != 1 {
+		t.Fatalf("Unexpected number of notifications: have=%d; want=1", len(d.Notifications()))
+	}
+
+	// Simulate a config file change enabling slack instead of banner.
+	v.Set("default", "slack")
+	d.reload()
+
+	if _, ok := d.Services()["slack"]; !ok {
+		t.Fatal("Expected slack service to be enabled after reload")
+	}
+	if _, ok := d.Services()["banner"]; ok {
+		t.Fatal("Expected banner service to be disabled after reload")
+	}
+
+	notis := d.Notifications()
+	if len(notis) != 1 || notis[0].Name != "slack" {
+		t.Fatalf("Unexpected notifications after reload: %+v", notis)
+	}
+}